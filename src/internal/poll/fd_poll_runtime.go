@@ -0,0 +1,82 @@
+//go:build (darwin || (linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2)) && !nintendoswitch
+
+package poll
+
+import (
+	"errors"
+	"sync"
+	_ "unsafe" // for go:linkname
+)
+
+// pollDesc contains the runtime state a descriptor needs to park a
+// goroutine on read/write readiness. It mirrors the gc runtime's
+// internal/poll.pollDesc: everything interesting lives on the runtime side
+// (see runtime/poll_runtime.go), this struct just holds the opaque handle
+// runtime_pollOpen handed back.
+type pollDesc struct {
+	runtimeCtx uintptr
+}
+
+var serverInit sync.Once
+
+func (pd *pollDesc) init(fd *FD) error {
+	serverInit.Do(runtime_pollServerInit)
+	ctx, errno := runtime_pollOpen(uintptr(fd.Sysfd))
+	if errno != 0 {
+		return errors.New("runtime_pollOpen failed")
+	}
+	pd.runtimeCtx = ctx
+	return nil
+}
+
+func (pd *pollDesc) close() {
+	if pd.runtimeCtx == 0 {
+		return
+	}
+	runtime_pollClose(pd.runtimeCtx)
+	pd.runtimeCtx = 0
+}
+
+// waitRead blocks until fd is readable, fd is closed, or an error occurs.
+func (pd *pollDesc) waitRead() error {
+	return pd.wait('r')
+}
+
+// waitWrite blocks until fd is writable, fd is closed, or an error occurs.
+func (pd *pollDesc) waitWrite() error {
+	return pd.wait('w')
+}
+
+func (pd *pollDesc) wait(mode int) error {
+	if pd.runtimeCtx == 0 {
+		return errors.New("waiting on uninitialized descriptor")
+	}
+	res := runtime_pollWait(pd.runtimeCtx, mode)
+	if res != 0 {
+		return ErrFileClosing
+	}
+	return nil
+}
+
+// evict makes any goroutine currently parked in waitRead/waitWrite on this
+// descriptor return immediately; it's called from FD.Close/FD.SetDeadline.
+func (pd *pollDesc) evict() {
+	if pd.runtimeCtx != 0 {
+		runtime_pollUnblock(pd.runtimeCtx)
+	}
+}
+
+// These are implemented in runtime/poll_runtime.go, and are wired in via
+// go:linkname using the exact symbol names below -- this file intentionally
+// doesn't implement them itself, matching how the gc runtime's internal/poll
+// package stays OS-agnostic and defers all of the actual polling to the
+// runtime package.
+func runtime_pollServerInit()
+func runtime_pollOpen(fd uintptr) (uintptr, int)
+func runtime_pollClose(ctx uintptr)
+func runtime_pollReset(ctx uintptr, mode int) int
+func runtime_pollWait(ctx uintptr, mode int) int
+func runtime_pollWaitCanceled(ctx uintptr, mode int)
+func runtime_pollSetDeadline(ctx uintptr, d int64, mode int)
+func runtime_pollUnblock(ctx uintptr)
+func runtime_isPollDescriptor(fd uintptr) bool
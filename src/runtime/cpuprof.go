@@ -0,0 +1,79 @@
+//go:build (darwin || (linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2)) && !nintendoswitch
+
+package runtime
+
+// This file implements the portable half of CPU profiling support: a
+// lock-free (well, drop-on-full, which is close enough for a sampling
+// profiler) ring buffer that the platform-specific SIGPROF handler appends
+// program counters to from signal context, and a drain step that forwards
+// those samples to runtime/pprof outside of signal context. The platform
+// halves (cpuprof_linux.go, cpuprof_darwin.go) are responsible for arming
+// the actual interval timer that generates SIGPROF.
+
+const cpuProfBufSize = 1024
+
+var (
+	cpuProfBuf  [cpuProfBufSize]uintptr
+	cpuProfHead uint32 // next slot to write; only touched from signal context
+	cpuProfTail uint32 // next slot to read; only touched by cpuProfDrain
+)
+
+// cpuProfRate is the last rate passed to setcpuprofilerate, or 0 if
+// profiling is currently disabled. cpuProfDrain (called from checkSignals)
+// reads it and returns at once while it is 0.
+var cpuProfRate int32
+
+// cpuProfChan is drained by a goroutine started from runtime/pprof;
+// buffered deep enough that a typical sampling burst doesn't block
+// cpuProfDrain.
+var cpuProfChan chan uintptr
+
+func init() {
+	cpuProfChan = make(chan uintptr, cpuProfBufSize)
+}
+
+// SetCPUProfileRate is called by runtime/pprof.StartCPUProfile/
+// StopCPUProfile to start or stop interval-timer based sampling at hz
+// samples per second; hz == 0 disables profiling. The actual timer setup
+// is platform-specific, see setcpuprofilerate in cpuprof_linux.go/
+// cpuprof_darwin.go.
+func SetCPUProfileRate(hz int) {
+	cpuProfRate = int32(hz)
+	setcpuprofilerate(int32(hz))
+}
+
+// cpuProfAddSample records one stack sample. It must be async-signal-safe:
+// no allocation, no locking, nothing that could reenter the allocator or
+// deadlock against whatever the interrupted goroutine was doing.
+func cpuProfAddSample(pc uintptr) {
+	next := (cpuProfHead + 1) % cpuProfBufSize
+	if next == cpuProfTail {
+		// The buffer is full and nothing has drained it yet; drop the
+		// sample rather than block in signal context.
+		return
+	}
+	cpuProfBuf[cpuProfHead] = pc
+	cpuProfHead = next
+}
+
+//export tinygo_sigprof_handler
+func tinygo_sigprof_handler(pc uintptr) {
+	cpuProfAddSample(pc)
+}
+
+// cpuProfDrain moves any buffered samples onto cpuProfChan. It is cheap
+// when there is nothing to do, so it's called from checkSignals, which
+// already runs on every scheduler pass that might otherwise block.
+func cpuProfDrain() {
+	if cpuProfRate == 0 {
+		return
+	}
+	for cpuProfTail != cpuProfHead {
+		select {
+		case cpuProfChan <- cpuProfBuf[cpuProfTail]:
+			cpuProfTail = (cpuProfTail + 1) % cpuProfBufSize
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//go:build darwin
+
+package runtime
+
+const _ITIMER_PROF = 2
+
+// timeval mirrors struct timeval on Darwin, where tv_usec is a 32-bit
+// suseconds_t even though tv_sec is 64-bit, hence the explicit padding.
+type timeval struct {
+	tv_sec  int64
+	tv_usec int32
+	_       [4]byte
+}
+
+type itimerval struct {
+	it_interval timeval
+	it_value    timeval
+}
+
+//export setitimer
+func libc_setitimer(which int32, new_value *itimerval, old_value *itimerval) int32
+
+// tinygo_sigprof_enable/tinygo_sigprof_disable install and remove a
+// dedicated SIGPROF handler that, from signal context, walks the
+// interrupted goroutine's stack and calls tinygo_sigprof_handler with each
+// PC. Without this, SIGPROF's default disposition applies -- it terminates
+// the process -- the moment the timer armed below first fires.
+//
+//export tinygo_sigprof_enable
+func tinygo_sigprof_enable()
+
+//export tinygo_sigprof_disable
+func tinygo_sigprof_disable()
+
+// setcpuprofilerate arms (hz > 0) or disarms (hz == 0) a process-wide
+// ITIMER_PROF timer that delivers SIGPROF. Darwin has no per-thread POSIX
+// interval timer like Linux's timer_create(SIGEV_THREAD_ID), so profiling
+// here is process-wide rather than per-M, same as gc's Darwin port.
+func setcpuprofilerate(hz int32) {
+	var it itimerval
+	if hz > 0 {
+		// The handler must be installed *before* the timer can possibly
+		// fire, otherwise the first tick runs with SIGPROF at its default
+		// (process-terminating) disposition.
+		tinygo_sigprof_enable()
+		period := int64(1000000) / int64(hz) // microseconds
+		it.it_value.tv_sec = period / 1000000
+		it.it_value.tv_usec = int32(period % 1000000)
+		it.it_interval = it.it_value
+	}
+	// hz == 0 leaves it zeroed, which disarms the timer.
+	if libc_setitimer(_ITIMER_PROF, &it, nil) != 0 {
+		runtimePanic("setitimer failed")
+	}
+	if hz <= 0 {
+		tinygo_sigprof_disable()
+	}
+}
@@ -0,0 +1,91 @@
+//go:build linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package runtime
+
+const (
+	_CLOCK_THREAD_CPUTIME_ID = 3
+	_SIGEV_THREAD_ID         = 4
+	_SIGPROF                 = 27
+)
+
+// sigevent mirrors struct sigevent as used by timer_create(2) with
+// SIGEV_THREAD_ID, i.e. only the fields glibc/musl actually read in that
+// mode are given real names; the rest is padding to match the platform's
+// struct size.
+type sigevent struct {
+	sigev_value            uintptr
+	sigev_signo            int32
+	sigev_notify           int32
+	sigev_notify_thread_id int32
+	_                      [44]byte
+}
+
+type itimerspec struct {
+	it_interval timespec
+	it_value    timespec
+}
+
+//export timer_create
+func timer_create(clockid int32, sevp *sigevent, timerid *uintptr) int32
+
+//export timer_settime
+func timer_settime(timerid uintptr, flags int32, new_value *itimerspec, old_value *itimerspec) int32
+
+//export timer_delete
+func timer_delete(timerid uintptr) int32
+
+//export gettid
+func gettid() int32
+
+// tinygo_sigprof_enable/tinygo_sigprof_disable install and remove a
+// dedicated SIGPROF handler (separate from the general-purpose
+// tinygo_signal_handler path used by os/signal) that, from signal context,
+// walks the interrupted goroutine's stack and calls tinygo_sigprof_handler
+// with each PC. Without this, SIGPROF's default disposition applies -- it
+// terminates the process -- the moment the timer armed below first fires.
+//
+//export tinygo_sigprof_enable
+func tinygo_sigprof_enable()
+
+//export tinygo_sigprof_disable
+func tinygo_sigprof_disable()
+
+// setcpuprofilerate arms (hz > 0) or disarms (hz == 0) a per-thread POSIX
+// interval timer on CLOCK_THREAD_CPUTIME_ID that delivers SIGPROF to this
+// specific thread via SIGEV_THREAD_ID, analogous to gc's setThreadCPUProfiler.
+func setcpuprofilerate(hz int32) {
+	if hz <= 0 {
+		if m0.profileTimerValid {
+			timer_delete(m0.profileTimer)
+			m0.profileTimerValid = false
+			tinygo_sigprof_disable()
+		}
+		return
+	}
+
+	if !m0.profileTimerValid {
+		// The handler must be installed *before* the timer can possibly
+		// fire, otherwise the first tick runs with SIGPROF at its default
+		// (process-terminating) disposition.
+		tinygo_sigprof_enable()
+
+		var sev sigevent
+		sev.sigev_notify = _SIGEV_THREAD_ID
+		sev.sigev_signo = _SIGPROF
+		sev.sigev_notify_thread_id = gettid()
+		if timer_create(_CLOCK_THREAD_CPUTIME_ID, &sev, &m0.profileTimer) != 0 {
+			tinygo_sigprof_disable()
+			runtimePanic("timer_create failed")
+		}
+		m0.profileTimerValid = true
+	}
+
+	period := int64(1000000000) / int64(hz)
+	var its itimerspec
+	its.it_value.tv_sec = period / 1000000000
+	its.it_value.tv_nsec = period % 1000000000
+	its.it_interval = its.it_value
+	if timer_settime(m0.profileTimer, 0, &its, nil) != 0 {
+		runtimePanic("timer_settime failed")
+	}
+}
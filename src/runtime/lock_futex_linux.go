@@ -0,0 +1,88 @@
+//go:build linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package runtime
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// mOS carries the per-M state needed to park an OS thread until there is
+// work for it, using a futex word as the wakeup primitive. TinyGo doesn't
+// run more than one M yet, but notesleep/notewakeup are written so that the
+// eventual multi-M scheduler (and sync.Mutex/sync.WaitGroup contention
+// paths) can block a real thread instead of spinning or yielding.
+type mOS struct {
+	note uint32
+
+	// CPU profiling timer state, set up by setcpuprofilerate in
+	// cpuprof_linux.go.
+	profileTimer      uintptr
+	profileTimerValid bool
+}
+
+// m0 is the (currently only) M, representing this OS thread.
+var m0 mOS
+
+const (
+	_FUTEX_WAIT_PRIVATE = 128
+	_FUTEX_WAKE_PRIVATE = 129
+)
+
+//export syscall
+func libc_syscall(trap uintptr, a1, a2, a3, a4, a5, a6 uintptr) uintptr
+
+// _SYS_futex is GOARCH-specific (e.g. 202 on amd64, 98 on arm64, which share
+// no syscall table) and is defined in lock_futex_linux_<arch>.go.
+
+// futexsleep atomically checks that *addr still equals val and, if so,
+// blocks the calling thread until woken by futexwakeup or, if ns >= 0,
+// until ns nanoseconds have passed. Spurious wakes, EAGAIN (the value
+// didn't match, meaning a wakeup already happened) and EINTR are all
+// treated as ordinary returns: the caller is expected to re-check its
+// condition in a loop.
+func futexsleep(addr *uint32, val uint32, ns int64) {
+	var ts timespec
+	var tsp *timespec
+	if ns >= 0 {
+		ts.tv_sec = ns / 1000000000
+		ts.tv_nsec = ns % 1000000000
+		tsp = &ts
+	}
+	libc_syscall(_SYS_futex, uintptr(unsafe.Pointer(addr)), _FUTEX_WAIT_PRIVATE, uintptr(val), uintptr(unsafe.Pointer(tsp)), 0, 0)
+}
+
+// futexwakeup wakes up to cnt threads blocked in futexsleep on addr.
+func futexwakeup(addr *uint32, cnt uint32) {
+	ret := int32(libc_syscall(_SYS_futex, uintptr(unsafe.Pointer(addr)), _FUTEX_WAKE_PRIVATE, uintptr(cnt), 0, 0, 0))
+	if ret < 0 {
+		runtimePanic("futexwakeup failed")
+	}
+}
+
+// notesleep blocks until notewakeup(mp) is called, or ns nanoseconds have
+// passed if ns >= 0. It is the portable name sleepTicks and the mutex
+// contention paths are written against; on Linux it is implemented directly
+// on top of a futex word stored in mp.note.
+func notesleep(mp *mOS, ns int64) {
+	if ns >= 0 {
+		// A timed wait only gets one attempt: the caller (sleepTicks)
+		// re-evaluates its own deadline on the next scheduler pass. Consume
+		// the note either way (whether we were actually woken or simply
+		// timed out) so a stale "woken" flag can't leak into the next,
+		// unrelated notesleep call and make it return immediately.
+		futexsleep(&mp.note, 0, ns)
+		atomic.CompareAndSwapUint32(&mp.note, 1, 0)
+		return
+	}
+	for atomic.LoadUint32(&mp.note) == 0 {
+		futexsleep(&mp.note, 0, -1)
+	}
+	atomic.StoreUint32(&mp.note, 0)
+}
+
+// notewakeup sets mp's note and wakes any thread parked in notesleep(mp).
+func notewakeup(mp *mOS) {
+	atomic.StoreUint32(&mp.note, 1)
+	futexwakeup(&mp.note, 1)
+}
@@ -0,0 +1,5 @@
+//go:build linux && amd64 && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package runtime
+
+const _SYS_futex = 202 // amd64 legacy syscall table
@@ -0,0 +1,5 @@
+//go:build linux && (arm64 || riscv64) && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package runtime
+
+const _SYS_futex = 98 // arm64 and riscv64 share the generic asm-generic/unistd.h table
@@ -0,0 +1,72 @@
+//go:build darwin
+
+package runtime
+
+// mOS carries the per-M state needed to park an OS thread until there is
+// work for it. Darwin has no futex, so each M gets its own Mach semaphore
+// instead; see notesleep/notewakeup below.
+type mOS struct {
+	sema uint32 // semaphore_t, a kernel port name
+}
+
+// m0 is the (currently only) M, representing this OS thread.
+var m0 mOS
+
+const (
+	_SYNC_POLICY_FIFO = 0
+)
+
+//export mach_task_self_
+var mach_task_self_ uint32
+
+//export semaphore_create
+func semaphore_create(task uint32, sema *uint32, policy int32, value int32) int32
+
+//export semaphore_destroy
+func semaphore_destroy(task uint32, sema uint32) int32
+
+//export semaphore_wait
+func semaphore_wait(sema uint32) int32
+
+//export semaphore_timedwait
+func semaphore_timedwait(sema uint32, t machTimespec) int32
+
+//export semaphore_signal
+func semaphore_signal(sema uint32) int32
+
+// machTimespec mirrors mach/mach_time.h's mach_timespec_t, which (unlike
+// struct timespec) uses plain 32-bit fields.
+type machTimespec struct {
+	tv_sec  uint32
+	tv_nsec int32
+}
+
+// initSema lazily creates mp's semaphore on first use.
+func initSema(mp *mOS) {
+	if mp.sema != 0 {
+		return
+	}
+	if semaphore_create(mach_task_self_, &mp.sema, _SYNC_POLICY_FIFO, 0) != 0 {
+		runtimePanic("semaphore_create failed")
+	}
+}
+
+// notesleep blocks until notewakeup(mp) is called, or ns nanoseconds have
+// passed if ns >= 0.
+func notesleep(mp *mOS, ns int64) {
+	initSema(mp)
+	if ns < 0 {
+		semaphore_wait(mp.sema)
+		return
+	}
+	var ts machTimespec
+	ts.tv_sec = uint32(ns / 1000000000)
+	ts.tv_nsec = int32(ns % 1000000000)
+	semaphore_timedwait(mp.sema, ts)
+}
+
+// notewakeup wakes the thread parked in notesleep(mp), if any.
+func notewakeup(mp *mOS) {
+	initSema(mp)
+	semaphore_signal(mp.sema)
+}
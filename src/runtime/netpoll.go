@@ -0,0 +1,139 @@
+//go:build (darwin || (linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2)) && !nintendoswitch
+
+package runtime
+
+import (
+	"internal/task"
+	"unsafe"
+)
+
+// pollDesc is the per-file-descriptor bookkeeping used by the netpoller. One
+// is created for every file descriptor that is put in non-blocking mode by
+// internal/poll, and it is reused for the lifetime of that descriptor.
+//
+// Unlike the gc runtime, tinygo does not (yet) have multiple OS threads
+// running goroutines in parallel, so there's no need for the lock-free
+// atomic state machine found in the upstream netpoller: a goroutine that
+// needs to wait for readability/writability simply parks its task and
+// netpoll() resumes it directly once epoll/kqueue reports the event.
+type pollDesc struct {
+	fd int32
+
+	// rt/wt are the parked tasks waiting for the descriptor to become
+	// readable/writable, or nil if no goroutine is currently waiting.
+	rt, wt *task.Task
+
+	closing bool
+}
+
+// mode bits passed to netpollarm.
+const (
+	pollModeRead = 1 << iota
+	pollModeWrite
+)
+
+// gList is a small list of goroutines (tasks) that have become runnable
+// because of an event the netpoller observed. It is modeled after the gList
+// type in the gc runtime's netpoller, adapted to tinygo's task package.
+type gList struct {
+	tasks []*task.Task
+}
+
+func (l *gList) push(t *task.Task) {
+	l.tasks = append(l.tasks, t)
+}
+
+func (l *gList) empty() bool {
+	return len(l.tasks) == 0
+}
+
+// pollOpenCount tracks how many file descriptors are currently registered
+// with the netpoller. sleepTicks/waitForEvents consult this to decide
+// whether they should call netpoll() instead of plain usleep()/pause().
+var pollOpenCount int
+
+// netpollopen registers fd with the OS-specific poller so that it can later
+// be armed with netpollarm. pd is the pollDesc that will be updated by
+// netpoll() when an event fires.
+func netpollopen(fd int32, pd *pollDesc) int32 {
+	pd.fd = fd
+	errno := netpollopenImpl(fd, pd)
+	if errno == 0 {
+		pollOpenCount++
+	}
+	return errno
+}
+
+// netpollclose removes fd from the poller. It is called when the last
+// reference to the underlying os/net file descriptor is closed.
+func netpollclose(fd int32) int32 {
+	errno := netpollcloseImpl(fd)
+	if errno == 0 && pollOpenCount > 0 {
+		pollOpenCount--
+	}
+	return errno
+}
+
+// netpollarm arms the given mode (pollModeRead and/or pollModeWrite) on pd,
+// so that the next call to netpoll may report it ready. internal/poll calls
+// this right before parking a goroutine on the descriptor.
+func netpollarm(pd *pollDesc, mode int32) {
+	netpollarmImpl(pd, mode)
+}
+
+// netpoll blocks for up to delay nanoseconds (or indefinitely if delay < 0,
+// or returns immediately if delay == 0) waiting for registered descriptors
+// to become ready, and returns the list of goroutines that should now be
+// resumed. It is called from sleepTicks/waitForEvents instead of
+// usleep/pause whenever pollOpenCount > 0.
+func netpoll(delay int64) gList {
+	return netpollImpl(delay)
+}
+
+// resumeNetpollGoroutines wakes every goroutine netpoll() decided was
+// ready. It also gives the signal handling code a chance to deliver any
+// signal that arrived via the wakeup pipe while we were polling.
+func resumeNetpollGoroutines(ready gList) {
+	for _, t := range ready.tasks {
+		t.Resume()
+	}
+	checkSignals()
+}
+
+// signalWakeupR/signalWakeupW are the two ends of the self-pipe used to
+// break netpoll() out of its wait promptly when a signal is delivered. The
+// write end is written to from tinygo_signal_handler (runtime_unix.go); the
+// read end is registered with the poller (see registerSignalWakeupFD,
+// implemented per-platform) so it shows up alongside ordinary I/O readiness
+// events, and drained by drainSignalWakeupPipe below once it does.
+var signalWakeupR, signalWakeupW int32 = -1, -1
+
+// setupSignalWakeup creates the self-pipe and registers its read end with
+// the netpoller. It is called once from main() after
+// tinygo_register_fatal_signals, so that sleepTicks/waitForEvents can rely
+// on netpoll() to also observe pending signals once at least one file
+// descriptor has been registered.
+func setupSignalWakeup() {
+	r, w, ok := makeSignalWakeupPipe()
+	if !ok {
+		return
+	}
+	signalWakeupR, signalWakeupW = r, w
+	registerSignalWakeupFD(signalWakeupR)
+}
+
+//export read
+func libc_read(fd int32, buf unsafe.Pointer, count uint) int
+
+// drainSignalWakeupPipe empties the self-pipe's read end after the poller
+// reports it readable. The pipe is non-blocking, so this always returns
+// promptly once it hits EAGAIN/EWOULDBLOCK (a negative return); every byte
+// in it is just a wakeup nudge, never a payload to interpret. Without this,
+// an edge-triggered poller would never re-arm and a level-triggered one
+// (epoll's default) would report the fd ready forever, turning every future
+// wait into a zero-timeout busy spin.
+func drainSignalWakeupPipe() {
+	var buf [64]byte
+	for signalWakeupR >= 0 && libc_read(signalWakeupR, unsafe.Pointer(&buf[0]), uint(len(buf))) > 0 {
+	}
+}
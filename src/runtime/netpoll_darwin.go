@@ -0,0 +1,182 @@
+//go:build darwin
+
+package runtime
+
+import (
+	"unsafe"
+)
+
+// kevent64_s mirrors the kernel's struct kevent64_s, which (unlike the plain
+// struct kevent) carries a 64-bit udata field on every architecture Darwin
+// runs on, which is convenient for storing a *pollDesc.
+type kevent64_s struct {
+	ident  uint64
+	filter int16
+	flags  uint16
+	fflags uint32
+	data   int64
+	udata  uint64
+	ext    [2]uint64
+}
+
+const (
+	_EVFILT_READ  = -1
+	_EVFILT_WRITE = -2
+
+	_EV_ADD     = 0x0001
+	_EV_DELETE  = 0x0002
+	_EV_CLEAR   = 0x0020
+	_EV_RECEIPT = 0x0040
+)
+
+//export kqueue
+func kqueue() int32
+
+//export kevent64
+func kevent64(kq int32, changelist *kevent64_s, nchanges int32, eventlist *kevent64_s, nevents int32, flags uint32, timeout *timespec) int32
+
+// kq is the single kqueue instance used by this process, created lazily.
+var kq int32 = -1
+
+func netpollkqueueinit() {
+	if kq >= 0 {
+		return
+	}
+	kq = kqueue()
+	if kq < 0 {
+		runtimePanic("netpoll: kqueue failed")
+	}
+}
+
+func netpollopenImpl(fd int32, pd *pollDesc) int32 {
+	netpollkqueueinit()
+	// Registration itself happens in netpollarmImpl once we know whether the
+	// caller wants to watch for reads, writes, or both; kqueue lets us
+	// register each filter independently.
+	return 0
+}
+
+func netpollcloseImpl(fd int32) int32 {
+	var changes [2]kevent64_s
+	changes[0] = kevent64_s{ident: uint64(fd), filter: _EVFILT_READ, flags: _EV_DELETE}
+	changes[1] = kevent64_s{ident: uint64(fd), filter: _EVFILT_WRITE, flags: _EV_DELETE}
+	kevent64(kq, &changes[0], 2, nil, 0, 0, nil)
+	return 0
+}
+
+func netpollarmImpl(pd *pollDesc, mode int32) {
+	var changes [2]kevent64_s
+	n := int32(0)
+	if mode&pollModeRead != 0 {
+		changes[n] = kevent64_s{
+			ident:  uint64(pd.fd),
+			filter: _EVFILT_READ,
+			flags:  _EV_ADD | _EV_CLEAR,
+			udata:  uint64(uintptr(unsafe.Pointer(pd))),
+		}
+		n++
+	}
+	if mode&pollModeWrite != 0 {
+		changes[n] = kevent64_s{
+			ident:  uint64(pd.fd),
+			filter: _EVFILT_WRITE,
+			flags:  _EV_ADD | _EV_CLEAR,
+			udata:  uint64(uintptr(unsafe.Pointer(pd))),
+		}
+		n++
+	}
+	if n > 0 {
+		kevent64(kq, &changes[0], n, nil, 0, 0, nil)
+	}
+}
+
+func netpollImpl(delay int64) gList {
+	var toRun gList
+	if kq < 0 {
+		return toRun
+	}
+
+	var ts timespec
+	var tsp *timespec
+	if delay >= 0 {
+		ts.tv_sec = delay / 1000000000
+		ts.tv_nsec = delay % 1000000000
+		tsp = &ts
+	}
+
+	var events [16]kevent64_s
+	n := kevent64(kq, nil, 0, &events[0], int32(len(events)), 0, tsp)
+	if n < 0 {
+		checkSignals()
+		return toRun
+	}
+
+	for i := int32(0); i < n; i++ {
+		ev := &events[i]
+		if ev.udata == 0 {
+			// The signal wakeup identifier, see registerSignalWakeupFD.
+			// EVFILT_READ is edge-reported here against a non-blocking pipe,
+			// but the byte still needs draining or the next write would pile
+			// up unread.
+			drainSignalWakeupPipe()
+			checkSignals()
+			continue
+		}
+		pd := (*pollDesc)(unsafe.Pointer(uintptr(ev.udata)))
+		switch ev.filter {
+		case _EVFILT_READ:
+			if pd.rt != nil {
+				toRun.push(pd.rt)
+				pd.rt = nil
+			}
+		case _EVFILT_WRITE:
+			if pd.wt != nil {
+				toRun.push(pd.wt)
+				pd.wt = nil
+			}
+		}
+	}
+	return toRun
+}
+
+const (
+	_F_GETFL    = 3
+	_F_SETFL    = 4
+	_F_SETFD    = 2
+	_FD_CLOEXEC = 1
+	_O_NONBLOCK = 0x0004
+)
+
+//export pipe
+func libc_pipe(fds *int32) int32
+
+//export fcntl
+func libc_fcntl(fd, cmd, arg int32) int32
+
+// makeSignalWakeupPipe creates the self-pipe with pipe(2) and then sets
+// O_NONBLOCK/FD_CLOEXEC on each end individually, since Darwin has no
+// pipe2(2).
+func makeSignalWakeupPipe() (r, w int32, ok bool) {
+	var fds [2]int32
+	if libc_pipe(&fds[0]) != 0 {
+		return 0, 0, false
+	}
+	for _, fd := range fds {
+		libc_fcntl(fd, _F_SETFL, libc_fcntl(fd, _F_GETFL, 0)|_O_NONBLOCK)
+		libc_fcntl(fd, _F_SETFD, _FD_CLOEXEC)
+	}
+	return fds[0], fds[1], true
+}
+
+// registerSignalWakeupFD arms the read end of the self-pipe used to break
+// out of kevent64 promptly when a signal arrives. It is registered with a
+// zero udata so netpollImpl can tell it apart from a real pollDesc.
+func registerSignalWakeupFD(fd int32) {
+	netpollkqueueinit()
+	change := kevent64_s{
+		ident:  uint64(fd),
+		filter: _EVFILT_READ,
+		flags:  _EV_ADD | _EV_CLEAR,
+	}
+	kevent64(kq, &change, 1, nil, 0, 0, nil)
+}
@@ -0,0 +1,161 @@
+//go:build linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package runtime
+
+import (
+	"unsafe"
+)
+
+// epoll_event as defined by the Linux kernel ABI. Note that on amd64 this
+// struct is packed (no padding between events and data); data is a byte
+// array so that Go inserts no padding either, which gives the same 12-byte
+// layout that libc's epoll_ctl/epoll_pwait expect there.
+type epollevent struct {
+	events uint32
+	data   [8]byte // opaque epoll_data_t, we stuff a *pollDesc in here
+}
+
+const (
+	_EPOLLIN  = 0x001
+	_EPOLLOUT = 0x004
+	_EPOLLERR = 0x008
+	_EPOLLHUP = 0x010
+
+	_EPOLL_CTL_ADD = 1
+	_EPOLL_CTL_DEL = 2
+	_EPOLL_CTL_MOD = 3
+
+	_EPOLL_CLOEXEC = 0x80000
+)
+
+//export epoll_create1
+func epoll_create1(flags int32) int32
+
+//export epoll_ctl
+func libc_epoll_ctl(epfd, op, fd int32, event *epollevent) int32
+
+//export epoll_pwait
+func libc_epoll_pwait(epfd int32, events *epollevent, maxevents, timeout int32, sigmask *uint64) int32
+
+// epfd is the single epoll instance used by this process. It is created
+// by whichever of registerSignalWakeupFD (called from main at startup) and
+// netpollopen runs first.
+var epfd int32 = -1
+
+func netpollepollinit() {
+	if epfd >= 0 {
+		return
+	}
+	epfd = epoll_create1(_EPOLL_CLOEXEC)
+	if epfd < 0 {
+		runtimePanic("netpoll: epoll_create1 failed")
+	}
+}
+
+func netpollopenImpl(fd int32, pd *pollDesc) int32 {
+	netpollepollinit()
+	var ev epollevent
+	*(**pollDesc)(unsafe.Pointer(&ev.data)) = pd
+	return libc_epoll_ctl(epfd, _EPOLL_CTL_ADD, fd, &ev)
+}
+
+func netpollcloseImpl(fd int32) int32 {
+	return libc_epoll_ctl(epfd, _EPOLL_CTL_DEL, fd, nil)
+}
+
+func netpollarmImpl(pd *pollDesc, mode int32) {
+	var events uint32
+	if mode&pollModeRead != 0 || pd.rt != nil {
+		events |= _EPOLLIN
+	}
+	if mode&pollModeWrite != 0 || pd.wt != nil {
+		events |= _EPOLLOUT
+	}
+	var ev epollevent
+	ev.events = events
+	*(**pollDesc)(unsafe.Pointer(&ev.data)) = pd
+	libc_epoll_ctl(epfd, _EPOLL_CTL_MOD, pd.fd, &ev)
+}
+
+// netpollImpl waits on the shared epoll instance (which also has the signal
+// wakeup pipe registered, see registerSignalWakeupFD below) and
+// returns the goroutines that should be resumed.
+func netpollImpl(delay int64) gList {
+	var toRun gList
+	if epfd < 0 {
+		return toRun
+	}
+
+	timeoutMS := int32(-1)
+	if delay == 0 {
+		timeoutMS = 0
+	} else if delay > 0 {
+		timeoutMS = int32(delay / 1000000)
+		if timeoutMS == 0 {
+			timeoutMS = 1
+		}
+	}
+
+	var events [16]epollevent
+retry:
+	n := libc_epoll_pwait(epfd, &events[0], int32(len(events)), timeoutMS, nil)
+	if n < 0 {
+		// Interrupted by a signal while waiting: the signal wakeup pipe
+		// (if registered) will have already produced a readable event, but
+		// if the pwait call itself returned EINTR, just retry with no
+		// further delay so we don't miss a pending signal.
+		checkSignals()
+		timeoutMS = 0
+		goto retry
+	}
+
+	for i := int32(0); i < n; i++ {
+		ev := &events[i]
+		pd := *(**pollDesc)(unsafe.Pointer(&ev.data))
+		if pd == nil {
+			// This is the signal wakeup pipe; drain it so epoll doesn't keep
+			// reporting it ready, then handle the signal itself through the
+			// normal checkSignals path.
+			drainSignalWakeupPipe()
+			checkSignals()
+			continue
+		}
+		if ev.events&(_EPOLLIN|_EPOLLERR|_EPOLLHUP) != 0 && pd.rt != nil {
+			toRun.push(pd.rt)
+			pd.rt = nil
+		}
+		if ev.events&(_EPOLLOUT|_EPOLLERR|_EPOLLHUP) != 0 && pd.wt != nil {
+			toRun.push(pd.wt)
+			pd.wt = nil
+		}
+	}
+	return toRun
+}
+
+const _O_NONBLOCK = 0x800
+const _O_CLOEXEC = 0x80000
+
+//export pipe2
+func pipe2(fds *int32, flags int32) int32
+
+// makeSignalWakeupPipe creates the self-pipe with O_NONBLOCK|O_CLOEXEC set
+// on both ends in a single syscall, as supported by Linux's pipe2(2).
+func makeSignalWakeupPipe() (r, w int32, ok bool) {
+	var fds [2]int32
+	if pipe2(&fds[0], _O_NONBLOCK|_O_CLOEXEC) != 0 {
+		return 0, 0, false
+	}
+	return fds[0], fds[1], true
+}
+
+// registerSignalWakeupFD arms the read end of the self-pipe used to break
+// out of epoll_pwait promptly when a signal arrives, so that checkSignals
+// can drain it on the same path as a regular I/O event.
+func registerSignalWakeupFD(fd int32) {
+	netpollepollinit()
+	var ev epollevent
+	ev.events = _EPOLLIN
+	// Leave ev.data as all zero (nil pollDesc) so netpollImpl recognizes
+	// this as the signal pipe rather than a real pollDesc.
+	libc_epoll_ctl(epfd, _EPOLL_CTL_ADD, fd, &ev)
+}
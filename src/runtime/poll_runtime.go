@@ -0,0 +1,120 @@
+//go:build (darwin || (linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2)) && !nintendoswitch
+
+package runtime
+
+import (
+	"internal/task"
+	"unsafe"
+)
+
+// This file is the actual wiring point between internal/poll and the
+// netpoller implemented in netpoll.go/netpoll_linux.go/netpoll_darwin.go:
+// internal/poll's fd_poll_runtime.go declares the functions below without
+// bodies, the go:linkname directives here supply them (under the same names
+// the gc runtime uses), and internal/poll calls them
+// from FD.init/FD.Read/FD.Write/FD.Close whenever a descriptor has been put
+// in non-blocking mode. Without this file, netpollopen/netpollarm/
+// netpollclose have no caller and pollOpenCount never leaves zero.
+
+const (
+	pollModeReadByte  = 'r'
+	pollModeWriteByte = 'w'
+)
+
+//go:linkname poll_runtime_pollServerInit internal/poll.runtime_pollServerInit
+func poll_runtime_pollServerInit() {
+	// Nothing to do: the epoll/kqueue fd is created by
+	// registerSignalWakeupFD at startup, or by netpollopenImpl if that
+	// runs first.
+}
+
+//go:linkname poll_runtime_pollOpen internal/poll.runtime_pollOpen
+func poll_runtime_pollOpen(fd uintptr) (uintptr, int) {
+	pd := new(pollDesc)
+	errno := netpollopen(int32(fd), pd)
+	if errno != 0 {
+		return 0, int(errno)
+	}
+	return uintptr(unsafe.Pointer(pd)), 0
+}
+
+//go:linkname poll_runtime_pollClose internal/poll.runtime_pollClose
+func poll_runtime_pollClose(ctx uintptr) {
+	pd := (*pollDesc)(unsafe.Pointer(ctx))
+	netpollclose(pd.fd)
+}
+
+//go:linkname poll_runtime_pollReset internal/poll.runtime_pollReset
+func poll_runtime_pollReset(ctx uintptr, mode int) int {
+	pd := (*pollDesc)(unsafe.Pointer(ctx))
+	if pd.closing {
+		return 1 // pollErrClosing, matches internal/poll's errno convention
+	}
+	return 0 // pollNoError
+}
+
+//go:linkname poll_runtime_pollWait internal/poll.runtime_pollWait
+func poll_runtime_pollWait(ctx uintptr, mode int) int {
+	pd := (*pollDesc)(unsafe.Pointer(ctx))
+	for {
+		if pd.closing {
+			return 1 // pollErrClosing
+		}
+
+		t := task.Current()
+		var armMode int32
+		if mode == pollModeReadByte {
+			pd.rt = t
+			armMode = pollModeRead
+		} else {
+			pd.wt = t
+			armMode = pollModeWrite
+		}
+		netpollarm(pd, armMode)
+
+		// Park until netpoll() observes the descriptor is ready (or the
+		// descriptor is closed out from under us) and resumes this task.
+		task.Pause()
+
+		if pd.closing {
+			return 1 // pollErrClosing: woken by poll_runtime_pollUnblock
+		}
+		if mode == pollModeReadByte && pd.rt == nil {
+			return 0
+		}
+		if mode == pollModeWriteByte && pd.wt == nil {
+			return 0
+		}
+		// Spurious resume (shouldn't normally happen): loop and re-arm.
+	}
+}
+
+//go:linkname poll_runtime_pollWaitCanceled internal/poll.runtime_pollWaitCanceled
+func poll_runtime_pollWaitCanceled(ctx uintptr, mode int) {
+}
+
+//go:linkname poll_runtime_pollSetDeadline internal/poll.runtime_pollSetDeadline
+func poll_runtime_pollSetDeadline(ctx uintptr, d int64, mode int) {
+	// TODO: arm a timer that calls poll_runtime_pollUnblock when d elapses.
+	// Until then, Read/WriteDeadline on a netpoll-backed descriptor block
+	// indefinitely instead of timing out.
+}
+
+//go:linkname poll_runtime_pollUnblock internal/poll.runtime_pollUnblock
+func poll_runtime_pollUnblock(ctx uintptr) {
+	pd := (*pollDesc)(unsafe.Pointer(ctx))
+	pd.closing = true
+	if pd.rt != nil {
+		pd.rt.Resume()
+		pd.rt = nil
+	}
+	if pd.wt != nil {
+		pd.wt.Resume()
+		pd.wt = nil
+	}
+}
+
+//go:linkname poll_runtime_isPollDescriptor internal/poll.runtime_isPollDescriptor
+func poll_runtime_isPollDescriptor(fd uintptr) bool {
+	return false
+}
@@ -86,6 +86,12 @@ func main(argc int32, argv *unsafe.Pointer) int {
 	// messages.
 	tinygo_register_fatal_signals()
 
+	// Set up the netpoller's signal wakeup pipe, so that sleepTicks/
+	// waitForEvents can use epoll_pwait/kevent64 to wait on both file
+	// descriptors and pending signals once internal/poll starts registering
+	// non-blocking descriptors.
+	setupSignalWakeup()
+
 	// Obtain the initial stack pointer right before calling the run() function.
 	// The run function has been moved to a separate (non-inlined) function so
 	// that the correct stack pointer is read.
@@ -153,16 +159,7 @@ func tinygo_handle_fatal_signal(sig int32, addr uintptr) {
 		printstring("panic: runtime error")
 	}
 	printstring(": caught signal ")
-	switch sig {
-	case sig_SIGBUS:
-		println("SIGBUS")
-	case sig_SIGILL:
-		println("SIGILL")
-	case sig_SIGSEGV:
-		println("SIGSEGV")
-	default:
-		println(sig)
-	}
+	println(sigName(sig))
 
 	// TODO: it might be interesting to also print the invalid address for
 	// SIGSEGV and SIGBUS.
@@ -222,10 +219,22 @@ func nanosecondsToTicks(ns int64) timeUnit {
 }
 
 func sleepTicks(d timeUnit) {
+	// If there are file descriptors parked in the netpoller, let it wait for
+	// both those and (via the signal wakeup pipe) pending signals instead of
+	// usleep/sigtimedwait, so a goroutine blocked on I/O wakes up promptly.
+	if pollOpenCount > 0 {
+		readyGoroutines := netpoll(int64(d))
+		resumeNetpollGoroutines(readyGoroutines)
+		return
+	}
+
 	// When there are no signal handlers present, we can simply go to sleep.
+	// Park on m0's note instead of calling usleep directly: on top of a
+	// futex/Mach semaphore this is the same wait with nanosecond precision,
+	// and it's the same primitive the eventual multi-M scheduler will use to
+	// wake an idle M, so sleepTicks already exercises it today.
 	if !hasSignals {
-		// timeUnit is in nanoseconds, so need to convert to microseconds here.
-		usleep(uint(d) / 1000)
+		notesleep(&m0, int64(d))
 		return
 	}
 
@@ -251,12 +260,12 @@ func sleepTicks(d timeUnit) {
 	} else {
 		// Linux (and various other POSIX systems) implement sigtimedwait so we
 		// can do this in a non-racy way.
-		tinygo_wfi_mask(activeSignals)
+		tinygo_wfi_mask(&activeSignals)
 		if checkSignals() {
 			tinygo_wfi_unmask()
 			return
 		}
-		signal := tinygo_wfi_sleep(activeSignals, uint64(d))
+		signal := tinygo_wfi_sleep(&activeSignals, uint64(d))
 		if signal >= 0 {
 			tinygo_signal_handler(signal)
 			checkSignals()
@@ -296,7 +305,11 @@ func syscall_Exit(code int) {
 }
 
 // TinyGo does not yet support any form of parallelism on an OS, so these can be
-// left empty.
+// left empty. Note that the building blocks for a future multi-M scheduler
+// (notesleep/notewakeup on top of futexes or Mach semaphores, see
+// lock_futex_linux.go/lock_sema_darwin.go) already exist; procPin/procUnpin
+// will need to start pinning the current goroutine to its M once more than
+// one M can run at a time.
 
 //go:linkname procPin sync/atomic.runtime_procPin
 func procPin() {
@@ -362,51 +375,70 @@ var signalChan chan uint32
 // Indicate whether signals have been registered.
 var hasSignals bool
 
+// sigWords is the number of 64-bit words used to track signal state. A
+// single word only has valid bit positions for signals 0..63 (s/64 >= 1
+// rejects s == 64), which isn't enough: on Linux SIGRTMAX is signal 64, and
+// sigtable_linux.go generates a table entry for it. Two words cover signals
+// 0..127, well past every platform tinygo's POSIX runtime targets (Darwin
+// only goes up to signal 31); it's simplest to share the same width
+// everywhere.
+const sigWords = 2
+
+// sigset is a fixed-size signal number bitset, indexed the same way on
+// every platform: bit (s % 64) of word (s / 64).
+type sigset [sigWords]uint64
+
+func (s *sigset) set(sig uint32)   { s[sig/64] |= 1 << (sig % 64) }
+func (s *sigset) clear(sig uint32) { s[sig/64] &^= 1 << (sig % 64) }
+
 // Mask of signals that have been received. The signal handler atomically ORs
-// signals into this value.
-var receivedSignals uint32
+// signals into this value, one atomic word per element of the array.
+var receivedSignals [sigWords]atomic.Uint64
 
-var activeSignals uint32
+// activeSignals is only ever mutated from regular goroutine context (by
+// signal_enable/signal_ignore/signal_disable below), never from a signal
+// handler, so unlike receivedSignals it doesn't need to be atomic.
+var activeSignals sigset
 
 //go:linkname signal_enable os/signal.signal_enable
 func signal_enable(s uint32) {
-	if s >= 32 {
-		// TODO: to support higher signal numbers, we need to turn
-		// receivedSignals into a uint32 array.
+	if s/64 >= sigWords {
 		runtimePanicAt(returnAddress(0), "unsupported signal number")
 	}
+	if int(s) < len(sigtable) && sigtable[s].flags&_SigNotify == 0 {
+		// Signals the table doesn't mark _SigNotify are not enabled: SIGKILL
+		// and SIGSTOP, which the kernel never delivers to a handler, and the
+		// synchronous fault signals such as SIGSEGV, SIGBUS and SIGILL.
+		return
+	}
 	hasSignals = true
-	activeSignals |= 1 << s
+	activeSignals.set(s)
 	// It's easier to implement this function in C.
 	tinygo_signal_enable(s)
 }
 
 //go:linkname signal_ignore os/signal.signal_ignore
 func signal_ignore(s uint32) {
-	if s >= 32 {
-		// TODO: to support higher signal numbers, we need to turn
-		// receivedSignals into a uint32 array.
+	if s/64 >= sigWords {
 		runtimePanicAt(returnAddress(0), "unsupported signal number")
 	}
-	activeSignals &^= 1 << s
+	activeSignals.clear(s)
 	tinygo_signal_ignore(s)
 }
 
 //go:linkname signal_disable os/signal.signal_disable
 func signal_disable(s uint32) {
-	if s >= 32 {
-		// TODO: to support higher signal numbers, we need to turn
-		// receivedSignals into a uint32 array.
+	if s/64 >= sigWords {
 		runtimePanicAt(returnAddress(0), "unsupported signal number")
 	}
-	activeSignals &^= 1 << s
+	activeSignals.clear(s)
 	tinygo_signal_disable(s)
 }
 
 //go:linkname signal_waitUntilIdle os/signal.signalWaitUntilIdle
 func signal_waitUntilIdle() {
 	// Make sure all signals are sent on the channel.
-	for atomic.LoadUint32(&receivedSignals) != 0 {
+	for receivedSignalsPending() {
 		checkSignals()
 		Gosched()
 	}
@@ -417,6 +449,15 @@ func signal_waitUntilIdle() {
 	}
 }
 
+func receivedSignalsPending() bool {
+	for i := range receivedSignals {
+		if receivedSignals[i].Load() != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 //export tinygo_signal_enable
 func tinygo_signal_enable(s uint32)
 
@@ -432,18 +473,28 @@ func tinygo_signal_disable(s uint32)
 func tinygo_signal_handler(s int32) {
 	// This loop is essentially the atomic equivalent of the following:
 	//
-	//   receivedSignals |= 1 << s
+	//   receivedSignals[word] |= 1 << bit
 	//
-	// TODO: use atomic.Uint32.And once we drop support for Go 1.22 instead of
-	// this loop.
+	// TODO: use atomic.Uint64.Or once we drop support for Go 1.22 instead of
+	// this loop. Must remain async-signal-safe: no allocation, no locking.
+	word := uint32(s) / 64
+	bit := uint64(1) << (uint32(s) % 64)
+	w := &receivedSignals[word]
 	for {
-		mask := uint32(1) << uint32(s)
-		val := atomic.LoadUint32(&receivedSignals)
-		swapped := atomic.CompareAndSwapUint32(&receivedSignals, val, val|mask)
-		if swapped {
+		val := w.Load()
+		if w.CompareAndSwap(val, val|bit) {
 			break
 		}
 	}
+
+	// Nudge netpoll() out of its wait promptly instead of leaving it to
+	// notice receivedSignals on its next naturally-scheduled timeout. A
+	// single byte is enough; if the pipe is full or not yet set up
+	// (signalWakeupW == -1) there's nothing useful to do with the error.
+	if signalWakeupW >= 0 {
+		b := byte(0)
+		libc_write(signalWakeupW, unsafe.Pointer(&b), 1)
+	}
 }
 
 //go:linkname signal_recv os/signal.signal_recv
@@ -458,16 +509,31 @@ func signal_recv() uint32 {
 // signalChan channel. Return true if at least one signal was delivered this
 // way, false otherwise.
 func checkSignals() bool {
+	// Cheap no-op when profiling is off; forwards any buffered SIGPROF
+	// samples to runtime/pprof otherwise. This runs on every scheduler pass
+	// that might otherwise block, which is frequent enough to keep the
+	// sample buffer from filling up under typical profiling rates.
+	cpuProfDrain()
+
 	gotSignals := false
 	for {
-		// Extract the lowest numbered signal number from receivedSignals.
-		val := atomic.LoadUint32(&receivedSignals)
-		if val == 0 {
+		// Extract the lowest numbered signal number from receivedSignals,
+		// scanning words from low to high.
+		word := -1
+		var val uint64
+		for i := range receivedSignals {
+			val = receivedSignals[i].Load()
+			if val != 0 {
+				word = i
+				break
+			}
+		}
+		if word < 0 {
 			// There is no signal ready to be received by the program (common
 			// case).
 			return gotSignals
 		}
-		num := uint32(bits.TrailingZeros32(val))
+		num := uint32(word*64 + bits.TrailingZeros64(val))
 
 		// Do a non-blocking send on signalChan.
 		select {
@@ -483,33 +549,45 @@ func checkSignals() bool {
 			return gotSignals
 		}
 
-		// Atomically clear the signal number from receivedSignals.
-		// TODO: use atomic.Uint32.Or once we drop support for Go 1.22 instead
+		// Atomically clear the signal number from its word.
+		// TODO: use atomic.Uint64.And once we drop support for Go 1.22 instead
 		// of this loop.
+		w := &receivedSignals[word]
+		bit := uint64(1) << (num % 64)
 		for {
-			newVal := val &^ (1 << num)
-			swapped := atomic.CompareAndSwapUint32(&receivedSignals, val, newVal)
-			if swapped {
+			newVal := val &^ bit
+			if w.CompareAndSwap(val, newVal) {
 				break
 			}
-			val = atomic.LoadUint32(&receivedSignals)
+			val = w.Load()
 		}
 	}
 }
 
+// tinygo_wfi_mask/sleep/wait take a pointer to the full activeSignals bitset
+// rather than a bare uint32, so that the C shim can build a complete
+// sigset_t (via sigemptyset/sigaddset for each set bit) instead of being
+// limited to the first 32 signals.
+
 //export tinygo_wfi_mask
-func tinygo_wfi_mask(active uint32)
+func tinygo_wfi_mask(active *sigset)
 
 //export tinygo_wfi_sleep
-func tinygo_wfi_sleep(active uint32, timeout uint64) int32
+func tinygo_wfi_sleep(active *sigset, timeout uint64) int32
 
 //export tinygo_wfi_wait
-func tinygo_wfi_wait(active uint32) int32
+func tinygo_wfi_wait(active *sigset) int32
 
 //export tinygo_wfi_unmask
 func tinygo_wfi_unmask()
 
 func waitForEvents() {
+	if pollOpenCount > 0 {
+		readyGoroutines := netpoll(-1)
+		resumeNetpollGoroutines(readyGoroutines)
+		return
+	}
+
 	if hasSignals {
 		// We could have used pause() here, but that function is impossible to
 		// use in a race-free way:
@@ -517,12 +595,12 @@ func waitForEvents() {
 		// Therefore we need something better.
 		// Note: this is unsafe with multithreading, because sigprocmask is only
 		// defined for single-threaded applictions.
-		tinygo_wfi_mask(activeSignals)
+		tinygo_wfi_mask(&activeSignals)
 		if checkSignals() {
 			tinygo_wfi_unmask()
 			return
 		}
-		signal := tinygo_wfi_wait(activeSignals)
+		signal := tinygo_wfi_wait(&activeSignals)
 		tinygo_signal_handler(signal)
 		checkSignals()
 		tinygo_wfi_unmask()
@@ -0,0 +1,24 @@
+//go:build (darwin || (linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2)) && !nintendoswitch
+
+package runtime
+
+import "testing"
+
+// TestSigSetSignal64 guards against the sigWords off-by-one that used to
+// make signal 64 (SIGRTMAX on Linux) unrepresentable: with sigWords == 1,
+// s/64 >= sigWords rejected s == 64 even though it's a valid signal number.
+func TestSigSetSignal64(t *testing.T) {
+	if 64/64 >= sigWords {
+		t.Fatal("sigWords is too small to represent signal 64 (SIGRTMAX)")
+	}
+
+	var s sigset
+	s.set(64)
+	if s[1]&1 == 0 {
+		t.Fatal("sigset.set(64) did not set bit 0 of word 1")
+	}
+	s.clear(64)
+	if s[1]&1 != 0 {
+		t.Fatal("sigset.clear(64) did not clear bit 0 of word 1")
+	}
+}
@@ -0,0 +1,64 @@
+//go:build darwin
+
+package runtime
+
+// See signal_fork_linux.go for the overall rationale; this is the Darwin
+// implementation of the same three hooks for syscall.ForkExec.
+
+// forkSigset mirrors Darwin's sigset_t, a plain 32-bit word.
+type forkSigset uint32
+
+//export pthread_sigmask
+func pthread_sigmask(how int32, set *forkSigset, oldset *forkSigset) int32
+
+//export sigfillset
+func sigfillset(set *forkSigset) int32
+
+//export sigaction
+func libc_fork_sigaction(sig int32, act *forkSigactionT, oldact *forkSigactionT) int32
+
+// forkSigactionT mirrors Darwin's struct sigaction.
+type forkSigactionT struct {
+	sa_handler uintptr
+	sa_mask    forkSigset
+	sa_flags   int32
+}
+
+const (
+	_SIG_SETMASK = 3
+	_SIG_DFL     = 0
+)
+
+// forkSavedMask stashes the signal mask across one fork/exec.
+var forkSavedMask forkSigset
+
+func beforeFork() {
+	var all forkSigset
+	sigfillset(&all)
+	pthread_sigmask(_SIG_SETMASK, &all, &forkSavedMask)
+}
+
+func afterForkInParent() {
+	pthread_sigmask(_SIG_SETMASK, &forkSavedMask, nil)
+}
+
+func afterForkInChild() {
+	resetSignalToDefault(sig_SIGBUS)
+	resetSignalToDefault(sig_SIGILL)
+	resetSignalToDefault(sig_SIGSEGV)
+	for word := range activeSignals {
+		mask := activeSignals[word]
+		for bit := 0; bit < 64; bit++ {
+			if mask&(1<<uint(bit)) != 0 {
+				resetSignalToDefault(int32(word*64 + bit))
+			}
+		}
+	}
+	pthread_sigmask(_SIG_SETMASK, &forkSavedMask, nil)
+}
+
+func resetSignalToDefault(sig int32) {
+	var act forkSigactionT
+	act.sa_handler = _SIG_DFL
+	libc_fork_sigaction(sig, &act, nil)
+}
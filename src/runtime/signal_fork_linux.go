@@ -0,0 +1,94 @@
+//go:build linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package runtime
+
+// This file gives syscall.forkAndExecInChild1 a way to keep TinyGo's signal
+// handlers from running in a half-initialized child process between fork
+// and execve. See beforeFork/afterForkInParent/afterForkInChild below;
+// syscall.ForkExec on Linux calls them around the raw fork(2)/execve(2)
+// pair. Every function here must be safe to call with most of the runtime
+// unavailable (no goroutine scheduling, no allocation), since between fork
+// and exec the child is still single-threaded by construction but may not
+// assume anything else about process state.
+
+// forkSigset mirrors Linux's kernel/glibc/musl sigset_t, which is 1024 bits
+// wide (stored as an array of unsigned long) to leave room for the
+// realtime signal range. The Go side never inspects individual bits, only
+// passes whole instances to pthread_sigmask/sigfillset, so the exact word
+// type doesn't matter as long as the size does.
+type forkSigset [128]byte
+
+//export pthread_sigmask
+func pthread_sigmask(how int32, set *forkSigset, oldset *forkSigset) int32
+
+//export sigfillset
+func sigfillset(set *forkSigset) int32
+
+//export sigaction
+func libc_fork_sigaction(sig int32, act *forkSigactionT, oldact *forkSigactionT) int32
+
+// forkSigactionT mirrors struct sigaction, with only the fields
+// resetSignalToDefault below actually needs.
+type forkSigactionT struct {
+	sa_handler uintptr
+	sa_mask    forkSigset
+	sa_flags   int32
+	_          [4]byte // padding before sa_restorer on amd64/arm64
+	sa_restorer uintptr
+}
+
+const (
+	_SIG_SETMASK = 2
+	_SIG_DFL     = 0
+)
+
+// forkSavedMask stashes the signal mask across one fork/exec. syscall's
+// ForkExec holds a process-wide lock for the duration of a fork, so there
+// is never more than one fork in flight and a single package-level
+// variable is enough.
+var forkSavedMask forkSigset
+
+// beforeFork blocks every signal before fork(2), so that nothing can
+// interrupt the narrow window between fork and execve, and so that the
+// child (see afterForkInChild) gets a chance to reset signal dispositions
+// before anything can be delivered to it.
+func beforeFork() {
+	var all forkSigset
+	sigfillset(&all)
+	pthread_sigmask(_SIG_SETMASK, &all, &forkSavedMask)
+}
+
+// afterForkInParent restores the signal mask saved by beforeFork, once
+// fork(2) has returned in the parent.
+func afterForkInParent() {
+	pthread_sigmask(_SIG_SETMASK, &forkSavedMask, nil)
+}
+
+// afterForkInChild runs in the child between fork(2) and execve(2). It
+// resets every signal TinyGo has installed a handler for -- both the fatal
+// signals from tinygo_register_fatal_signals and anything enabled through
+// os/signal.Notify -- back to SIG_DFL, then restores the saved mask, so
+// that a signal delivered to the process group before execve is handled by
+// the kernel default rather than jumping into tinygo_signal_handler in a
+// process with no valid goroutine state. Must only call
+// async-signal-safe functions.
+func afterForkInChild() {
+	resetSignalToDefault(sig_SIGBUS)
+	resetSignalToDefault(sig_SIGILL)
+	resetSignalToDefault(sig_SIGSEGV)
+	for word := range activeSignals {
+		mask := activeSignals[word]
+		for bit := 0; bit < 64; bit++ {
+			if mask&(1<<uint(bit)) != 0 {
+				resetSignalToDefault(int32(word*64 + bit))
+			}
+		}
+	}
+	pthread_sigmask(_SIG_SETMASK, &forkSavedMask, nil)
+}
+
+func resetSignalToDefault(sig int32) {
+	var act forkSigactionT
+	act.sa_handler = _SIG_DFL
+	libc_fork_sigaction(sig, &act, nil)
+}
@@ -0,0 +1,65 @@
+//go:build (darwin || (linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2)) && !nintendoswitch
+
+package runtime
+
+// Flag bits for sigTabEntry.flags, modeled one-for-one on the gc runtime's
+// sigtable (see src/runtime/sigtab_linux_generic.go upstream). Exactly one
+// of _SigThrow/_SigPanic/_SigDefault/_SigIgn would normally apply to a
+// given signal; _SigNotify/_SigKill/_SigUnblock are independent modifiers.
+const (
+	_SigNotify  = 1 << iota // let os/signal.Notify see this signal
+	_SigKill                // if not notified, the default action is to kill the process
+	_SigThrow               // if not notified, the default action is to crash with a stack trace
+	_SigUnblock             // always unblocked, even while the signal handler itself runs
+	_SigPanic               // if not notified, the signal turns into a runtime panic
+	_SigDefault             // if not notified, leave the signal at its inherited (pre-exec) disposition
+	_SigIgn                 // if not notified, the signal is ignored entirely
+)
+
+// sigTabEntry is one row of the per-OS sigtable below.
+type sigTabEntry struct {
+	flags uint32
+	name  string
+}
+
+// sigtable is populated per-OS in sigtable_linux.go/sigtable_darwin.go.
+// Index 0 is unused (signal numbers are 1-based); sigtable[s] describes
+// signal s for every s up to len(sigtable)-1. Signals beyond that range
+// (sigWords in runtime_unix.go admits signal numbers up to 127) fall back
+// to a generic "signal %d" in tinygo_handle_fatal_signal and sigName below.
+var sigtable []sigTabEntry
+
+// sigName returns a human-readable name for signal s, falling back to a
+// bare number if s is outside the generated table (for example an
+// out-of-range value passed to kill(2) by a misbehaving caller).
+func sigName(s int32) string {
+	if s >= 0 && int(s) < len(sigtable) && sigtable[s].name != "" {
+		return sigtable[s].name
+	}
+	return "signal " + itoa(int(s))
+}
+
+// itoa is a tiny decimal formatter so sigName doesn't need to pull in
+// strconv (which isn't necessarily usable this early during a fatal
+// signal).
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	var buf [20]byte
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = byte('0' + n%10)
+		n /= 10
+	}
+	if neg {
+		i--
+		buf[i] = '-'
+	}
+	return string(buf[i:])
+}
@@ -0,0 +1,22 @@
+//go:build darwin
+
+package syscall
+
+// Raw syscall numbers used by forkAndExecInChild1, taken from Darwin's BSD
+// syscall table (bsd/kern/syscalls.master). Darwin has no exit_group, so
+// sysExitGroup is SYS_exit.
+const (
+	sysFork      = 2
+	sysChdir     = 12
+	sysExecve    = 59
+	sysExitGroup = 1
+)
+
+// rawFork issues the raw fork(2) syscall. Unlike Linux, Darwin's BSD syscall
+// numbers are stable across GOARCH (they come from the same
+// bsd/kern/syscalls.master table on amd64 and arm64), so there's no
+// per-arch variant to dispatch on here.
+func rawFork() (pid uintptr, errno Errno) {
+	pid, _, errno = RawSyscall(sysFork, 0, 0, 0)
+	return
+}
@@ -0,0 +1,19 @@
+//go:build linux && amd64 && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package syscall
+
+// Raw syscall numbers from the legacy x86_64 table, used by
+// forkAndExecInChild1.
+const (
+	sysChdir     = 80
+	sysExecve    = 59
+	sysExitGroup = 231
+	sysFork      = 57
+)
+
+// rawFork issues the raw fork(2) syscall, which amd64 Linux still exposes
+// directly (unlike arm64, where it was dropped in favor of clone(2)).
+func rawFork() (pid uintptr, errno Errno) {
+	pid, _, errno = RawSyscall(sysFork, 0, 0, 0)
+	return
+}
@@ -0,0 +1,24 @@
+//go:build linux && arm64 && !baremetal && !wasip1 && !wasm_unknown && !wasip2
+
+package syscall
+
+// Raw syscall numbers from arm64's generic (asm-generic/unistd.h) table,
+// used by forkAndExecInChild1. These do not match amd64's legacy table at
+// all -- e.g. 57 and 59 here would be close and pipe2, not fork and execve.
+const (
+	sysChdir     = 49
+	sysExecve    = 221
+	sysExitGroup = 94
+	sysClone     = 220
+)
+
+const _SIGCHLD = 17
+
+// rawFork emulates fork(2) via clone(2): arm64 never had a bare fork
+// syscall, and glibc's own fork() is itself clone(SIGCHLD, 0, 0, 0, 0) here.
+// Passing only SIGCHLD as the exit signal, with no CLONE_* sharing flags,
+// gives child semantics identical to fork(2).
+func rawFork() (pid uintptr, errno Errno) {
+	pid, _, errno = RawSyscall(sysClone, _SIGCHLD, 0, 0)
+	return
+}
@@ -0,0 +1,76 @@
+//go:build (darwin || (linux && !baremetal && !wasip1 && !wasm_unknown && !wasip2)) && !nintendoswitch
+
+package syscall
+
+import (
+	"unsafe"
+)
+
+// runtime_BeforeFork/runtime_AfterFork/runtime_AfterForkInChild are wired to
+// runtime.beforeFork/afterForkInParent/afterForkInChild (see
+// runtime/signal_fork_linux.go and runtime/signal_fork_darwin.go), which
+// block every signal across fork(2) and reset dispositions in the child so
+// that a signal delivered between fork and execve is handled by the kernel
+// default instead of jumping into TinyGo's signal handlers in a child that
+// has no valid goroutine state.
+//
+//go:linkname runtime_BeforeFork runtime.beforeFork
+func runtime_BeforeFork()
+
+//go:linkname runtime_AfterFork runtime.afterForkInParent
+func runtime_AfterFork()
+
+//go:linkname runtime_AfterForkInChild runtime.afterForkInChild
+func runtime_AfterForkInChild()
+
+// forkAndExecInChild1 forks the process and, in the child, execs argv0 with
+// argv/envv. It is the minimal, allocation-free core of ForkExec: by the
+// time it runs, argv0/argv/envv/dir have already been copied into flat,
+// nil-terminated C-style buffers by the caller, so everything from here to
+// execve only needs to call async-signal-safe functions.
+//
+// The signal handling calls below bracket the raw fork(2) the same way the
+// gc runtime's forkAndExecInChild1 does on Linux and Darwin: block signals,
+// fork, and in the child reset every signal disposition TinyGo has touched
+// back to SIG_DFL before anything else can run.
+func forkAndExecInChild1(argv0 *byte, argv, envv []*byte, dir *byte) (pid int, err Errno) {
+	runtime_BeforeFork()
+
+	pid1, errno := rawFork()
+	if errno != 0 {
+		runtime_AfterFork()
+		return 0, errno
+	}
+
+	if pid1 != 0 {
+		// Parent: restore our own signal mask and hand back the child pid.
+		runtime_AfterFork()
+		return int(pid1), 0
+	}
+
+	// Child: reset every signal TinyGo installed a handler for back to
+	// SIG_DFL, then restore the pre-fork mask.
+	runtime_AfterForkInChild()
+
+	if dir != nil {
+		if _, _, errno := RawSyscall(sysChdir, uintptr(unsafe.Pointer(dir)), 0, 0); errno != 0 {
+			rawExitChild(errno)
+		}
+	}
+
+	_, _, errno = RawSyscall(sysExecve,
+		uintptr(unsafe.Pointer(argv0)),
+		uintptr(unsafe.Pointer(&argv[0])),
+		uintptr(unsafe.Pointer(&envv[0])))
+	// execve only returns on failure.
+	rawExitChild(errno)
+	panic("unreachable")
+}
+
+// rawExitChild terminates the forked child immediately after a failed
+// preparatory syscall, without running any Go runtime shutdown code (there
+// is none to run safely here: the child shares the parent's heap but not
+// its other OS threads).
+func rawExitChild(errno Errno) {
+	RawSyscall(sysExitGroup, uintptr(errno), 0, 0)
+}
@@ -0,0 +1,165 @@
+// Command gensigtable regenerates src/runtime/sigtable_<goos>.go from the
+// host's libc signal headers. It is normally run once per supported OS
+// whenever a libc/SDK upgrade changes signal numbering, not as part of a
+// regular build.
+//
+// Usage:
+//
+//	go run tools/gensigtable.go linux   > src/runtime/sigtable_linux.go
+//	go run tools/gensigtable.go darwin  > src/runtime/sigtable_darwin.go
+//
+// On Linux it scrapes #define SIGxxx N lines out of
+// /usr/include/bits/signum-generic.h, /usr/include/bits/signum-arch.h,
+// /usr/include/asm-generic/signal.h and /usr/include/signal.h, in that
+// order, skipping headers that don't exist and keeping the first number
+// seen for each name; on Darwin it scrapes
+// /usr/include/sys/signal.h. The flags assigned to each signal (_SigNotify,
+// _SigKill, _SigThrow, ...) are not derivable from the header and are kept
+// in a small lookup table in this file instead, mirroring how the gc
+// runtime's own sigtable generator works.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+)
+
+var defineRE = regexp.MustCompile(`^\s*#\s*define\s+(SIG[A-Z0-9]+)\s+(\d+)\b`)
+
+// knownFlags gives the disposition flags for every signal name this tool
+// knows how to annotate; anything not listed here defaults to _SigThrow,
+// which is the conservative choice for a signal gensigtable has never seen
+// before.
+var knownFlags = map[string]string{
+	"SIGHUP": "_SigNotify + _SigKill", "SIGINT": "_SigNotify + _SigKill",
+	"SIGQUIT": "_SigNotify + _SigThrow", "SIGILL": "_SigThrow + _SigUnblock",
+	"SIGTRAP": "_SigThrow + _SigUnblock", "SIGABRT": "_SigNotify + _SigThrow",
+	"SIGEMT": "_SigThrow", "SIGBUS": "_SigPanic + _SigUnblock",
+	"SIGFPE": "_SigPanic + _SigUnblock", "SIGKILL": "0",
+	"SIGUSR1": "_SigNotify", "SIGSEGV": "_SigPanic + _SigUnblock",
+	"SIGUSR2": "_SigNotify", "SIGPIPE": "_SigNotify", "SIGALRM": "_SigNotify",
+	"SIGTERM": "_SigNotify + _SigKill", "SIGSTKFLT": "_SigThrow + _SigUnblock",
+	"SIGCHLD": "_SigNotify + _SigUnblock + _SigIgn", "SIGCLD": "_SigNotify + _SigUnblock + _SigIgn",
+	"SIGCONT": "_SigNotify + _SigDefault", "SIGSTOP": "0",
+	"SIGTSTP": "_SigNotify + _SigDefault", "SIGTTIN": "_SigNotify + _SigDefault",
+	"SIGTTOU": "_SigNotify + _SigDefault", "SIGURG": "_SigNotify + _SigIgn",
+	"SIGXCPU": "_SigNotify + _SigIgn", "SIGXFSZ": "_SigNotify + _SigIgn",
+	"SIGVTALRM": "_SigNotify + _SigIgn", "SIGPROF": "_SigNotify",
+	"SIGWINCH": "_SigNotify + _SigIgn", "SIGIO": "_SigNotify", "SIGPOLL": "_SigNotify",
+	"SIGPWR": "_SigNotify + _SigIgn", "SIGSYS": "_SigNotify + _SigThrow",
+	"SIGINFO": "_SigNotify + _SigIgn",
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gensigtable <linux|darwin>")
+		os.Exit(1)
+	}
+	goos := os.Args[1]
+
+	var headers []string
+	switch goos {
+	case "linux":
+		headers = []string{
+			"/usr/include/bits/signum-generic.h",
+			"/usr/include/bits/signum-arch.h",
+			"/usr/include/asm-generic/signal.h",
+			"/usr/include/signal.h",
+		}
+	case "darwin":
+		headers = []string{"/usr/include/sys/signal.h"}
+	default:
+		fmt.Fprintf(os.Stderr, "unsupported GOOS %q\n", goos)
+		os.Exit(1)
+	}
+
+	numbers := map[string]int{}
+	for _, h := range headers {
+		scanHeader(h, numbers)
+	}
+	if len(numbers) == 0 {
+		fmt.Fprintf(os.Stderr, "gensigtable: found no SIG* #defines in any of %v; "+
+			"is this running on a %s machine with libc headers installed?\n", headers, goos)
+		os.Exit(1)
+	}
+
+	names := make([]string, 0, len(numbers))
+	for name := range numbers {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return numbers[names[i]] < numbers[names[j]] })
+
+	max := 0
+	for _, n := range numbers {
+		if n > max {
+			max = n
+		}
+	}
+
+	// glibc/musl define SIGRTMIN/SIGRTMAX via __libc_current_sigrtmin()/
+	// __libc_current_sigrtmax(), not a plain #define N, so defineRE never
+	// matches them and the realtime range (34..64 on every Linux arch
+	// tinygo targets) would otherwise be silently dropped. Force the table
+	// to cover it explicitly instead of relying on what was scraped.
+	const rtMin, rtMax = 34, 64
+	if goos == "linux" && rtMax > max {
+		max = rtMax
+	}
+
+	fmt.Printf("// Code generated by tools/gensigtable from host libc headers. DO NOT EDIT.\n")
+	fmt.Printf("// Run `go run tools/gensigtable.go %s` from the repository root to regenerate.\n\n", goos)
+	fmt.Printf("package runtime\n\n")
+	fmt.Printf("func init() {\n\tsigtable = %sSigtable[:]\n}\n\n", goos)
+	fmt.Printf("var %sSigtable = [%d]sigTabEntry{\n", goos, max+1)
+	for _, name := range names {
+		n := numbers[name]
+		flags, ok := knownFlags[name]
+		if !ok {
+			flags = "_SigThrow" // unrecognized signal: be conservative
+		}
+		fmt.Printf("\t/* %2d */ {%s, %q},\n", n, flags, name)
+	}
+	fmt.Printf("}\n")
+
+	if goos == "linux" {
+		fmt.Printf("\n// Fill in SIGRTMIN..SIGRTMAX (%d..%d) as realtime signals: these all\n", rtMin, rtMax)
+		fmt.Printf("// support os/signal.Notify and, unless notified, are ignored by default.\n")
+		fmt.Printf("// Not scraped from the headers above: glibc/musl expose these via\n")
+		fmt.Printf("// __libc_current_sigrtmin()/__libc_current_sigrtmax(), not a #define.\n")
+		fmt.Printf("func init() {\n")
+		fmt.Printf("\tfor n := %d; n <= %d; n++ {\n", rtMin, rtMax)
+		fmt.Printf("\t\tname := \"SIGRTMIN+\" + itoa(n-%d)\n", rtMin)
+		fmt.Printf("\t\tif n == %d {\n", rtMin)
+		fmt.Printf("\t\t\tname = \"SIGRTMIN\"\n")
+		fmt.Printf("\t\t} else if n == %d {\n", rtMax)
+		fmt.Printf("\t\t\tname = \"SIGRTMAX\"\n")
+		fmt.Printf("\t\t}\n")
+		fmt.Printf("\t\t%sSigtable[n] = sigTabEntry{_SigNotify + _SigIgn, name}\n", goos)
+		fmt.Printf("\t}\n")
+		fmt.Printf("}\n")
+	}
+}
+
+func scanHeader(path string, out map[string]int) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := defineRE.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		var n int
+		fmt.Sscanf(m[2], "%d", &n)
+		if _, exists := out[m[1]]; !exists {
+			out[m[1]] = n
+		}
+	}
+}